@@ -0,0 +1,113 @@
+package actors
+
+// CommitGenesisSectorParams isn't in this package's cbor-gen type list yet,
+// so these encoders are hand-written in the same shape cbor-gen would
+// produce. Register the type and regenerate from here once the genesis
+// params are wired into that list, instead of hand-maintaining this file.
+
+import (
+	"fmt"
+	"io"
+
+	cbg "github.com/whyrusleeping/cbor-gen"
+	xerrors "golang.org/x/xerrors"
+)
+
+func (t *CommitGenesisSectorParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+
+	if _, err := w.Write(cbg.CborEncodeMajorType(cbg.MajArray, 5)); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(cbg.CborEncodeMajorType(cbg.MajUnsignedInt, t.SectorID)); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(cbg.CborEncodeMajorType(cbg.MajByteString, uint64(len(t.CommR)))); err != nil {
+		return err
+	}
+	if _, err := w.Write(t.CommR[:]); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(cbg.CborEncodeMajorType(cbg.MajByteString, uint64(len(t.CommD)))); err != nil {
+		return err
+	}
+	if _, err := w.Write(t.CommD[:]); err != nil {
+		return err
+	}
+
+	if len(t.DealIDs) > cbg.MaxLength {
+		return xerrors.Errorf("t.DealIDs slice was too long")
+	}
+	if _, err := w.Write(cbg.CborEncodeMajorType(cbg.MajArray, uint64(len(t.DealIDs)))); err != nil {
+		return err
+	}
+	for _, v := range t.DealIDs {
+		if _, err := w.Write(cbg.CborEncodeMajorType(cbg.MajUnsignedInt, v)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.Write(cbg.CborEncodeMajorType(cbg.MajUnsignedInt, t.Size)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *CommitGenesisSectorParams) UnmarshalCBOR(r io.Reader) error {
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || extra != 5 {
+		return fmt.Errorf("cbor input for CommitGenesisSectorParams was not an array of the expected length")
+	}
+
+	t.SectorID, err = cbg.ReadUInt(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if err := cbg.ReadFixedByteArray(br, scratch, t.CommR[:]); err != nil {
+		return err
+	}
+
+	if err := cbg.ReadFixedByteArray(br, scratch, t.CommD[:]); err != nil {
+		return err
+	}
+
+	maj, dealCount, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input for t.DealIDs was not an array")
+	}
+	if dealCount > uint64(cbg.MaxLength) {
+		return fmt.Errorf("t.DealIDs slice was too long")
+	}
+
+	t.DealIDs = make([]uint64, dealCount)
+	for i := range t.DealIDs {
+		t.DealIDs[i], err = cbg.ReadUInt(br, scratch)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.Size, err = cbg.ReadUInt(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
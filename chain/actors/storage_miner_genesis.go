@@ -0,0 +1,77 @@
+package actors
+
+import (
+	"github.com/filecoin-project/lotus/chain/types"
+	"golang.org/x/xerrors"
+)
+
+// SMAMethods enumerates the storage market/storage miner actor methods
+// reachable through chain messages. CommitGenesisSector joins the table
+// next to the storage market methods it's invoked alongside in
+// chain/gen.SetupStorageMiners.
+var SMAMethods = struct {
+	CreateStorageMiner  uint64
+	UpdateStorage       uint64
+	CommitGenesisSector uint64
+}{
+	CreateStorageMiner:  1,
+	UpdateStorage:       2,
+	CommitGenesisSector: 3,
+}
+
+// CommitGenesisSectorParams are the parameters to the storage miner actor's
+// CommitGenesisSector method (SMAMethods.CommitGenesisSector): they
+// describe a sector that was sealed offline ahead of time, so it can be
+// accepted into a miner's proving set without running PoRep verification.
+//
+// DealIDs is carried through for forward compatibility with the real
+// CommitSector params it mirrors, but CommitGenesisSector does not act on
+// it: genesis pre-seals aren't backed by storage market deals, so there's
+// nothing to associate the sector with yet.
+type CommitGenesisSectorParams struct {
+	SectorID uint64
+	CommR    [32]byte
+	CommD    [32]byte
+	DealIDs  []uint64
+	Size     uint64
+}
+
+// CommitGenesisSector adds a pre-sealed sector straight into the calling
+// miner's sector set and proving set, and grows its power by the sector's
+// declared size. Unlike CommitSector, it performs no PoRep verification,
+// which makes it unsuitable for anything but genesis construction (see
+// chain/gen.SetupStorageMiners). It is gated on block height and on the
+// caller being the miner itself so it can't be replayed against a live
+// chain to mint free power.
+//
+// Like CommitSector, it's a method on StorageMinerActor so the VM invoker
+// picks it up by reflection the same way it dispatches every other
+// SMAMethods entry; SMAMethods.CommitGenesisSector is its method number.
+func (sma StorageMinerActor) CommitGenesisSector(act *types.Actor, vmctx types.VMContext, params *CommitGenesisSectorParams) ([]byte, error) {
+	if vmctx.BlockHeight() != 0 {
+		return nil, xerrors.Errorf("CommitGenesisSector may only be called while constructing the genesis block, got height %d", vmctx.BlockHeight())
+	}
+	if vmctx.Message().From != vmctx.Message().To {
+		return nil, xerrors.Errorf("CommitGenesisSector may only be called by the miner actor on itself")
+	}
+
+	var mstate StorageMinerActorState
+	if err := vmctx.Storage().Get(act.Head, &mstate); err != nil {
+		return nil, err
+	}
+
+	mstate.Sectors = append(mstate.Sectors, params.SectorID)
+	mstate.ProvingSet = append(mstate.ProvingSet, params.SectorID)
+	mstate.Power = types.BigAdd(mstate.Power, types.NewInt(params.Size))
+
+	// params.DealIDs is intentionally unused: see the doc comment on
+	// CommitGenesisSectorParams.
+
+	nroot, err := vmctx.Storage().Put(&mstate)
+	if err != nil {
+		return nil, err
+	}
+
+	act.Head = nroot
+	return nil, nil
+}
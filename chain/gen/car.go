@@ -0,0 +1,60 @@
+package gen
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/filecoin-project/lotus/chain/types"
+	"golang.org/x/xerrors"
+
+	blockservice "github.com/ipfs/go-blockservice"
+	car "github.com/ipfs/go-car"
+	cid "github.com/ipfs/go-cid"
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	merkledag "github.com/ipfs/go-merkledag"
+)
+
+// ExportGenesisCAR serializes the full genesis state reachable from gb's
+// header (state tree, init actor HAMT, storage market actor, per-miner
+// actor heads, the empty sharray root, and the msgmeta block) into a single
+// CAR file rooted at the genesis block's CID. Nodes that start from the
+// same CAR always end up with byte-identical genesis state.
+func ExportGenesisCAR(ctx context.Context, bs bstore.Blockstore, gb *GenesisBootstrap, w io.Writer) error {
+	root := gb.Genesis.Cid()
+
+	bserv := blockservice.New(bs, offline.Exchange(bs))
+	dserv := merkledag.NewDAGService(bserv)
+
+	if err := car.WriteCar(ctx, dserv, []cid.Cid{root}, w); err != nil {
+		return xerrors.Errorf("writing genesis car: %w", err)
+	}
+
+	return nil
+}
+
+// ImportGenesisCAR loads a genesis previously written by ExportGenesisCAR
+// into bs and returns its GenesisBootstrap.
+func ImportGenesisCAR(ctx context.Context, bs bstore.Blockstore, r io.Reader) (*GenesisBootstrap, error) {
+	ch, err := car.LoadCar(bs, r)
+	if err != nil {
+		return nil, xerrors.Errorf("loading genesis car: %w", err)
+	}
+
+	if len(ch.Roots) != 1 {
+		return nil, xerrors.Errorf("expected genesis car to have exactly one root, got %d", len(ch.Roots))
+	}
+
+	blk, err := bs.Get(ch.Roots[0])
+	if err != nil {
+		return nil, xerrors.Errorf("loading genesis block: %w", err)
+	}
+
+	var gen types.BlockHeader
+	if err := gen.UnmarshalCBOR(bytes.NewReader(blk.RawData())); err != nil {
+		return nil, xerrors.Errorf("decoding genesis block header: %w", err)
+	}
+
+	return &GenesisBootstrap{Genesis: &gen}, nil
+}
@@ -0,0 +1,97 @@
+package gen
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	actors "github.com/filecoin-project/lotus/chain/actors"
+	"github.com/filecoin-project/lotus/chain/address"
+	"github.com/filecoin-project/lotus/chain/store"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/vm"
+
+	cid "github.com/ipfs/go-cid"
+	datastore "github.com/ipfs/go-datastore"
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// TestExportImportGenesisCARRoundTrip builds a genesis with one funded
+// account and one pre-sealed miner, so the exported CAR has to carry more
+// than a bare header: the state tree, the init actor, the storage market
+// actor and the genesis miner's actor must all come along with it and be
+// reachable from the root CID after import.
+func TestExportImportGenesisCARRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	bs := bstore.NewBlockstore(datastore.NewMapDatastore())
+
+	owner := address.Address{}
+	balances := map[address.Address]types.BigInt{
+		owner: types.NewInt(1000),
+	}
+	gmcfg := &GenMinerCfg{
+		Owners:      []address.Address{owner},
+		Workers:     []address.Address{owner},
+		PeerIDs:     []peer.ID{peer.ID("")},
+		SectorSizes: []uint64{1024},
+		PreSeals: [][]PreSealedSector{
+			{{SectorID: 1, Size: 1024}},
+		},
+	}
+
+	gb, err := MakeGenesisBlock(bs, balances, gmcfg, 1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gmcfg.MinerAddrs) != 1 {
+		t.Fatalf("expected one genesis miner to be created, got %d", len(gmcfg.MinerAddrs))
+	}
+
+	var buf bytes.Buffer
+	if err := ExportGenesisCAR(ctx, bs, gb, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	importBs := bstore.NewBlockstore(datastore.NewMapDatastore())
+	got, err := ImportGenesisCAR(ctx, importBs, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Genesis.Cid() != gb.Genesis.Cid() {
+		t.Fatalf("expected genesis cid %s, got %s", gb.Genesis.Cid(), got.Genesis.Cid())
+	}
+
+	blocks := map[string]cid.Cid{
+		"state root":       gb.Genesis.StateRoot,
+		"msgmeta":          gb.Genesis.Messages,
+		"message receipts": gb.Genesis.MessageReceipts,
+	}
+	for name, c := range blocks {
+		has, err := importBs.Has(c)
+		if err != nil {
+			t.Fatalf("checking for %s block: %s", name, err)
+		}
+		if !has {
+			t.Fatalf("expected imported car to contain the %s block (%s)", name, c)
+		}
+	}
+
+	ics := store.NewChainStore(importBs, datastore.NewMapDatastore())
+	ivm, err := vm.NewVM(gb.Genesis.StateRoot, 0, actors.NetworkAddress, ics)
+	if err != nil {
+		t.Fatalf("loading imported state tree: %s", err)
+	}
+
+	actorAddrs := map[string]address.Address{
+		"init actor":           actors.InitActorAddress,
+		"storage market actor": actors.StorageMarketAddress,
+		"genesis miner":        gmcfg.MinerAddrs[0],
+	}
+	for name, addr := range actorAddrs {
+		if _, err := ivm.StateTree().GetActor(addr); err != nil {
+			t.Fatalf("expected %s to be reachable from the imported genesis: %s", name, err)
+		}
+	}
+}
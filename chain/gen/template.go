@@ -0,0 +1,118 @@
+package gen
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/filecoin-project/lotus/chain/address"
+	"github.com/filecoin-project/lotus/chain/types"
+	"golang.org/x/xerrors"
+
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// GenesisTemplate is a declarative description of a genesis block. It lets
+// operators check a testnet's genesis into version control and reproduce the
+// same network deterministically, instead of wiring up a GenMinerCfg and a
+// balances map by hand.
+type GenesisTemplate struct {
+	Accounts []TemplateAccount
+	Miners   []TemplateMiner
+
+	Timestamp uint64
+}
+
+// TemplateAccount describes a single funded account in the genesis state
+// tree.
+type TemplateAccount struct {
+	Addr    address.Address
+	Balance types.BigInt
+}
+
+// TemplateMiner describes a single genesis storage miner and the sectors
+// that were pre-sealed for it offline. A miner's starting power is derived
+// from these pre-seals rather than declared directly.
+type TemplateMiner struct {
+	Owner  address.Address
+	Worker address.Address
+	PeerID peer.ID
+
+	SectorSize uint64
+
+	PreSeals []PreSealedSector
+}
+
+// PreSealedSector describes a sector that was sealed ahead of time, whose
+// commitments should be accepted into a genesis miner's proving set without
+// re-running PoRep verification.
+//
+// DealIDs is not currently populated by lotus-seed and is ignored by
+// CommitGenesisSector: genesis pre-seals carry no backing storage market
+// deals. It's kept on the struct so templates stay forward-compatible if
+// that changes.
+type PreSealedSector struct {
+	SectorID uint64
+	CommR    [32]byte
+	CommD    [32]byte
+	DealIDs  []uint64
+	Size     uint64
+}
+
+// LoadGenesisTemplate reads and parses a GenesisTemplate from a JSON file on
+// disk.
+func LoadGenesisTemplate(path string) (*GenesisTemplate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.Errorf("opening genesis template: %w", err)
+	}
+	defer f.Close()
+
+	var t GenesisTemplate
+	if err := json.NewDecoder(f).Decode(&t); err != nil {
+		return nil, xerrors.Errorf("decoding genesis template: %w", err)
+	}
+
+	return &t, nil
+}
+
+// MakeGenesisBlockFromTemplate builds a genesis block from a declarative
+// GenesisTemplate, routing through the same MakeInitialStateTree and
+// SetupStorageMiners paths used by MakeGenesisBlock. If carOut is
+// non-empty, the resulting genesis is also exported to that path as a CAR
+// file, so it can be handed to other nodes for byte-identical bootstrap.
+func MakeGenesisBlockFromTemplate(bs bstore.Blockstore, tmpl *GenesisTemplate, carOut string) (*GenesisBootstrap, error) {
+	balances := make(map[address.Address]types.BigInt)
+	for _, a := range tmpl.Accounts {
+		balances[a.Addr] = a.Balance
+	}
+
+	gmcfg := &GenMinerCfg{}
+	for _, m := range tmpl.Miners {
+		gmcfg.Owners = append(gmcfg.Owners, m.Owner)
+		gmcfg.Workers = append(gmcfg.Workers, m.Worker)
+		gmcfg.PeerIDs = append(gmcfg.PeerIDs, m.PeerID)
+		gmcfg.SectorSizes = append(gmcfg.SectorSizes, m.SectorSize)
+		gmcfg.PreSeals = append(gmcfg.PreSeals, m.PreSeals)
+	}
+
+	gb, err := MakeGenesisBlock(bs, balances, gmcfg, tmpl.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	if carOut != "" {
+		f, err := os.Create(carOut)
+		if err != nil {
+			return nil, xerrors.Errorf("creating genesis car file: %w", err)
+		}
+		defer f.Close()
+
+		if err := ExportGenesisCAR(context.Background(), bs, gb, f); err != nil {
+			return nil, xerrors.Errorf("exporting genesis car: %w", err)
+		}
+	}
+
+	return gb, nil
+}
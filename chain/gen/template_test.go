@@ -0,0 +1,58 @@
+package gen
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestLoadGenesisTemplateRoundTrip(t *testing.T) {
+	tmpl := &GenesisTemplate{
+		Timestamp: 1234,
+		Miners: []TemplateMiner{
+			{
+				SectorSize: 1024,
+				PreSeals: []PreSealedSector{
+					{SectorID: 1, DealIDs: []uint64{7}, Size: 1024},
+				},
+			},
+		},
+	}
+
+	f, err := ioutil.TempFile("", "genesis-template-*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+
+	if err := json.NewEncoder(f).Encode(tmpl); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadGenesisTemplate(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Timestamp != tmpl.Timestamp {
+		t.Fatalf("expected timestamp %d, got %d", tmpl.Timestamp, loaded.Timestamp)
+	}
+
+	if len(loaded.Miners) != 1 || loaded.Miners[0].SectorSize != 1024 {
+		t.Fatalf("miner sector size did not round-trip: %+v", loaded.Miners)
+	}
+
+	if len(loaded.Miners[0].PreSeals) != 1 || loaded.Miners[0].PreSeals[0].Size != 1024 {
+		t.Fatalf("miner pre-seals did not round-trip: %+v", loaded.Miners[0].PreSeals)
+	}
+}
+
+func TestLoadGenesisTemplateMissingFile(t *testing.T) {
+	if _, err := LoadGenesisTemplate("/nonexistent/genesis-template.json"); err == nil {
+		t.Fatal("expected an error for a missing template file")
+	}
+}
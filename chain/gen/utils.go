@@ -1,16 +1,18 @@
 package gen
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-
-	"github.com/filecoin-project/go-lotus/build"
-	actors "github.com/filecoin-project/go-lotus/chain/actors"
-	"github.com/filecoin-project/go-lotus/chain/address"
-	"github.com/filecoin-project/go-lotus/chain/state"
-	"github.com/filecoin-project/go-lotus/chain/store"
-	"github.com/filecoin-project/go-lotus/chain/types"
-	"github.com/filecoin-project/go-lotus/chain/vm"
+	"sort"
+
+	"github.com/filecoin-project/lotus/build"
+	actors "github.com/filecoin-project/lotus/chain/actors"
+	"github.com/filecoin-project/lotus/chain/address"
+	"github.com/filecoin-project/lotus/chain/state"
+	"github.com/filecoin-project/lotus/chain/store"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/vm"
 	"golang.org/x/xerrors"
 
 	"github.com/ipfs/go-cid"
@@ -80,6 +82,13 @@ func MakeInitialStateTree(bs bstore.Blockstore, actmap map[address.Address]types
 		addrs = append(addrs, a)
 	}
 
+	// Map iteration order is randomized, but the order in which addresses
+	// are handed to SetupInitActor determines their assigned ID. Sort them
+	// first so that the same actmap always produces the same genesis state.
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+
 	initact, err := SetupInitActor(bs, addrs)
 	if err != nil {
 		return nil, xerrors.Errorf("setup init actor: %w", err)
@@ -172,6 +181,17 @@ type GenMinerCfg struct {
 	MinerAddrs []address.Address
 
 	PeerIDs []peer.ID
+
+	// SectorSizes holds, for each miner in the same order as
+	// Owners/Workers/PeerIDs, the sector size it should be created with.
+	SectorSizes []uint64
+
+	// PreSeals holds, for each miner in the same order as
+	// Owners/Workers/PeerIDs, the sectors that were sealed for it offline.
+	// These are committed into the miner's proving set at genesis via
+	// CommitGenesisSector, which is what gives the miner its starting
+	// power.
+	PreSeals [][]PreSealedSector
 }
 
 func mustEnc(i cbg.CBORMarshaler) []byte {
@@ -196,7 +216,7 @@ func SetupStorageMiners(ctx context.Context, cs *store.ChainStore, sroot cid.Cid
 		params := mustEnc(&actors.CreateStorageMinerParams{
 			Owner:      owner,
 			Worker:     worker,
-			SectorSize: types.NewInt(build.SectorSize),
+			SectorSize: types.NewInt(gmcfg.SectorSizes[i]),
 			PeerID:     pid,
 		})
 
@@ -212,43 +232,28 @@ func SetupStorageMiners(ctx context.Context, cs *store.ChainStore, sroot cid.Cid
 
 		gmcfg.MinerAddrs = append(gmcfg.MinerAddrs, maddr)
 
-		params = mustEnc(&actors.UpdateStorageParams{Delta: types.NewInt(5000)})
-
-		_, err = doExec(ctx, vm, actors.StorageMarketAddress, maddr, actors.SMAMethods.UpdateStorage, params)
-		if err != nil {
-			return cid.Undef, xerrors.Errorf("failed to update total storage: %w", err)
-		}
-
-		// UGLY HACKY MODIFICATION OF MINER POWER
-
-		// we have to flush the vm here because it buffers stuff internally for perf reasons
-		if _, err := vm.Flush(ctx); err != nil {
-			return cid.Undef, xerrors.Errorf("vm.Flush failed: %w", err)
-		}
-
-		st := vm.StateTree()
-		mact, err := st.GetActor(maddr)
-		if err != nil {
-			return cid.Undef, xerrors.Errorf("get miner actor failed: %w", err)
-		}
-
-		cst := hamt.CSTFromBstore(cs.Blockstore())
-		var mstate actors.StorageMinerActorState
-		if err := cst.Get(ctx, mact.Head, &mstate); err != nil {
-			return cid.Undef, xerrors.Errorf("getting miner actor state failed: %w", err)
-		}
-		mstate.Power = types.NewInt(5000)
-
-		nstate, err := cst.Put(ctx, &mstate)
-		if err != nil {
-			return cid.Undef, err
-		}
-
-		mact.Head = nstate
-		if err := st.SetActor(maddr, mact); err != nil {
-			return cid.Undef, err
+		for _, ps := range gmcfg.PreSeals[i] {
+			params := mustEnc(&actors.CommitGenesisSectorParams{
+				SectorID: ps.SectorID,
+				CommR:    ps.CommR,
+				CommD:    ps.CommD,
+				DealIDs:  ps.DealIDs,
+				Size:     ps.Size,
+			})
+
+			// maddr, not worker, is the caller here: worker is never given
+			// its own funded actor, so doExec's nonce lookup would fail on
+			// it. The miner actor is already live at this point (it was
+			// just created above), so it can call into its own method.
+			if _, err := doExec(ctx, vm, maddr, maddr, actors.SMAMethods.CommitGenesisSector, params); err != nil {
+				return cid.Undef, xerrors.Errorf("failed to commit genesis sector: %w", err)
+			}
+
+			usParams := mustEnc(&actors.UpdateStorageParams{Delta: types.NewInt(ps.Size)})
+			if _, err := doExec(ctx, vm, actors.StorageMarketAddress, maddr, actors.SMAMethods.UpdateStorage, usParams); err != nil {
+				return cid.Undef, xerrors.Errorf("failed to update total storage: %w", err)
+			}
 		}
-		// End of super haxx
 	}
 
 	return vm.Flush(ctx)
@@ -0,0 +1,179 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os/exec"
+
+	"golang.org/x/xerrors"
+)
+
+// Action is fired when a probe reports an unhealthy result at a severity
+// the action is configured for.
+type Action interface {
+	Name() string
+	Fire(ctx context.Context, probe string, res ProbeResult) error
+}
+
+// actionFactories maps an action's config `type` to a constructor that
+// builds it from the other keys of the action's config entry.
+var actionFactories = map[string]func(params map[string]interface{}) (Action, error){
+	"systemd":      newSystemdRestartAction,
+	"exec":         newExecAction,
+	"webhook":      newWebhookAction,
+	"alertmanager": newAlertmanagerAction,
+	"log":          newLogAction,
+}
+
+type SystemdRestartAction struct {
+	Unit string
+}
+
+func newSystemdRestartAction(params map[string]interface{}) (Action, error) {
+	unit := paramString(params, "unit", "")
+	if unit == "" {
+		return nil, xerrors.Errorf("systemd action requires a unit")
+	}
+	return &SystemdRestartAction{Unit: unit}, nil
+}
+
+func (a *SystemdRestartAction) Name() string { return "systemd:" + a.Unit }
+
+func (a *SystemdRestartAction) Fire(ctx context.Context, probe string, res ProbeResult) error {
+	out, err := exec.CommandContext(ctx, "systemctl", "restart", a.Unit).CombinedOutput()
+	if err != nil {
+		return xerrors.Errorf("restarting %s: %w: %s", a.Unit, err, out)
+	}
+	return nil
+}
+
+type ExecAction struct {
+	Command string
+	Args    []string
+}
+
+func newExecAction(params map[string]interface{}) (Action, error) {
+	cmd := paramString(params, "command", "")
+	if cmd == "" {
+		return nil, xerrors.Errorf("exec action requires a command")
+	}
+	return &ExecAction{Command: cmd, Args: paramStringSlice(params, "args")}, nil
+}
+
+func (a *ExecAction) Name() string { return "exec:" + a.Command }
+
+func (a *ExecAction) Fire(ctx context.Context, probe string, res ProbeResult) error {
+	args := append(append([]string{}, a.Args...), probe, string(res.Severity), res.Message)
+	out, err := exec.CommandContext(ctx, a.Command, args...).CombinedOutput()
+	if err != nil {
+		return xerrors.Errorf("running %s: %w: %s", a.Command, err, out)
+	}
+	return nil
+}
+
+type WebhookAction struct {
+	URL string
+}
+
+func newWebhookAction(params map[string]interface{}) (Action, error) {
+	url := paramString(params, "url", "")
+	if url == "" {
+		return nil, xerrors.Errorf("webhook action requires a url")
+	}
+	return &WebhookAction{URL: url}, nil
+}
+
+func (a *WebhookAction) Name() string { return "webhook:" + a.URL }
+
+func (a *WebhookAction) Fire(ctx context.Context, probe string, res ProbeResult) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"probe":    probe,
+		"severity": res.Severity,
+		"message":  res.Message,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return xerrors.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type AlertmanagerAction struct {
+	URL string
+}
+
+func newAlertmanagerAction(params map[string]interface{}) (Action, error) {
+	url := paramString(params, "url", "")
+	if url == "" {
+		return nil, xerrors.Errorf("alertmanager action requires a url")
+	}
+	return &AlertmanagerAction{URL: url}, nil
+}
+
+func (a *AlertmanagerAction) Name() string { return "alertmanager:" + a.URL }
+
+func (a *AlertmanagerAction) Fire(ctx context.Context, probe string, res ProbeResult) error {
+	alert := []map[string]interface{}{
+		{
+			"labels": map[string]string{
+				"alertname": probe,
+				"severity":  string(res.Severity),
+			},
+			"annotations": map[string]string{
+				"message": res.Message,
+			},
+		},
+	}
+
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL+"/api/v2/alerts", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return xerrors.Errorf("alertmanager returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type LogAction struct{}
+
+func newLogAction(params map[string]interface{}) (Action, error) {
+	return &LogAction{}, nil
+}
+
+func (a *LogAction) Name() string { return "log" }
+
+func (a *LogAction) Fire(ctx context.Context, probe string, res ProbeResult) error {
+	log.Warnf("probe %s: [%s] %s", probe, res.Severity, res.Message)
+	return nil
+}
@@ -0,0 +1,94 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookActionPostsJSONBody(t *testing.T) {
+	var got map[string]interface{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	a := &WebhookAction{URL: srv.URL}
+	err := a.Fire(context.Background(), "peer-count", ProbeResult{
+		Severity: SeverityWarn,
+		Message:  "only 1 peer connected",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got["probe"] != "peer-count" {
+		t.Fatalf("expected probe peer-count, got %v", got["probe"])
+	}
+	if got["severity"] != string(SeverityWarn) {
+		t.Fatalf("expected severity warn, got %v", got["severity"])
+	}
+}
+
+func TestWebhookActionNonOKStatusIsError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	a := &WebhookAction{URL: srv.URL}
+	if err := a.Fire(context.Background(), "peer-count", ProbeResult{}); err == nil {
+		t.Fatal("expected an error for a non-2xx webhook response")
+	}
+}
+
+func TestAlertmanagerActionPostsToAlertsEndpoint(t *testing.T) {
+	var gotPath string
+	var alerts []map[string]interface{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&alerts); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	a := &AlertmanagerAction{URL: srv.URL}
+	err := a.Fire(context.Background(), "mpool-backlog", ProbeResult{
+		Severity: SeverityCrit,
+		Message:  "too many pending messages",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotPath != "/api/v2/alerts" {
+		t.Fatalf("expected post to /api/v2/alerts, got %s", gotPath)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("expected a single alert, got %d", len(alerts))
+	}
+	if alerts[0]["labels"].(map[string]interface{})["alertname"] != "mpool-backlog" {
+		t.Fatalf("expected alertname mpool-backlog, got %+v", alerts[0])
+	}
+}
+
+func TestAlertmanagerActionNonOKStatusIsError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	a := &AlertmanagerAction{URL: srv.URL}
+	if err := a.Fire(context.Background(), "mpool-backlog", ProbeResult{}); err == nil {
+		t.Fatal("expected an error for a non-2xx alertmanager response")
+	}
+}
@@ -0,0 +1,70 @@
+package health
+
+import (
+	"context"
+
+	"github.com/filecoin-project/lotus/api"
+	cid "github.com/ipfs/go-cid"
+)
+
+type ChainHeadStalled struct {
+	Threshold int
+
+	window [][]cid.Cid
+}
+
+func newChainHeadStalled(params map[string]interface{}) (Probe, error) {
+	return &ChainHeadStalled{
+		Threshold: paramInt(params, "threshold", 3),
+	}, nil
+}
+
+func (p *ChainHeadStalled) Name() string {
+	return "chain-head-stalled"
+}
+
+func (p *ChainHeadStalled) Check(ctx context.Context, a api.FullNode) (ProbeResult, error) {
+	head, err := a.ChainHead(ctx)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+
+	p.window = appendCIDsToWindow(p.window, head.Cids(), p.Threshold)
+
+	if headStalled(p.window, p.Threshold) {
+		return ProbeResult{
+			Severity: SeverityCrit,
+			Message:  "chain head has not advanced",
+		}, nil
+	}
+
+	return ProbeResult{Healthy: true}, nil
+}
+
+// keeps at most the last t entries
+func appendCIDsToWindow(w [][]cid.Cid, c []cid.Cid, t int) [][]cid.Cid {
+	w = append(w, c)
+	if len(w) > t {
+		w = w[len(w)-t:]
+	}
+	return w
+}
+
+func headStalled(window [][]cid.Cid, threshold int) bool {
+	if len(window) < threshold {
+		return false
+	}
+
+	last := window[len(window)-1]
+	for _, w := range window[:len(window)-1] {
+		if len(w) != len(last) {
+			return false
+		}
+		for j := range w {
+			if w[j] != last[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
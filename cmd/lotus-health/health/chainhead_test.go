@@ -0,0 +1,43 @@
+package health
+
+import (
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+)
+
+func TestAppendCIDsToWindowKeepsThreshold(t *testing.T) {
+	var w [][]cid.Cid
+	c := []cid.Cid{}
+
+	for i := 0; i < 5; i++ {
+		w = appendCIDsToWindow(w, c, 3)
+	}
+
+	if len(w) != 3 {
+		t.Fatalf("expected window capped at 3, got %d", len(w))
+	}
+}
+
+func TestHeadStalledBelowThreshold(t *testing.T) {
+	window := [][]cid.Cid{{}, {}}
+	if headStalled(window, 3) {
+		t.Fatal("expected not stalled before the window fills up")
+	}
+}
+
+func TestHeadStalledSameHead(t *testing.T) {
+	window := [][]cid.Cid{{}, {}, {}}
+	if !headStalled(window, 3) {
+		t.Fatal("expected stalled when every entry in the window is identical")
+	}
+}
+
+func TestHeadStalledChangingHead(t *testing.T) {
+	window := [][]cid.Cid{{}, {}, {}}
+	window[2] = []cid.Cid{cid.Undef}
+
+	if headStalled(window, 3) {
+		t.Fatal("expected not stalled when the head has changed")
+	}
+}
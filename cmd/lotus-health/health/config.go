@@ -0,0 +1,98 @@
+package health
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/filecoin-project/lotus/api"
+	yaml "gopkg.in/yaml.v2"
+
+	"golang.org/x/xerrors"
+)
+
+// rawConfig is the on-disk YAML shape of a health config.
+type rawConfig struct {
+	Actions map[string]rawAction `yaml:"actions"`
+	Probes  []rawProbe           `yaml:"probes"`
+}
+
+type rawAction struct {
+	Type   string                 `yaml:"type"`
+	Params map[string]interface{} `yaml:",inline"`
+}
+
+type rawProbe struct {
+	Type     string                 `yaml:"type"`
+	Interval string                 `yaml:"interval"`
+	Actions  map[string][]string    `yaml:"actions"`
+	Params   map[string]interface{} `yaml:",inline"`
+}
+
+// LoadRunner reads a health config from path and builds a Runner that checks
+// napi against it.
+func LoadRunner(path string, napi api.FullNode) (*Runner, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, xerrors.Errorf("reading health config: %w", err)
+	}
+
+	var raw rawConfig
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return nil, xerrors.Errorf("parsing health config: %w", err)
+	}
+
+	actions := make(map[string]Action, len(raw.Actions))
+	for name, ac := range raw.Actions {
+		factory, ok := actionFactories[ac.Type]
+		if !ok {
+			return nil, xerrors.Errorf("unknown action type %q for action %q", ac.Type, name)
+		}
+
+		act, err := factory(ac.Params)
+		if err != nil {
+			return nil, xerrors.Errorf("building action %q: %w", name, err)
+		}
+		actions[name] = act
+	}
+
+	r := &Runner{api: napi}
+	for _, pc := range raw.Probes {
+		factory, ok := probeFactories[pc.Type]
+		if !ok {
+			return nil, xerrors.Errorf("unknown probe type %q", pc.Type)
+		}
+
+		probe, err := factory(pc.Params)
+		if err != nil {
+			return nil, xerrors.Errorf("building probe %q: %w", pc.Type, err)
+		}
+
+		interval := 45 * time.Second
+		if pc.Interval != "" {
+			interval, err = time.ParseDuration(pc.Interval)
+			if err != nil {
+				return nil, xerrors.Errorf("parsing interval for probe %q: %w", pc.Type, err)
+			}
+		}
+
+		pr := &probeRun{
+			probe:    probe,
+			interval: interval,
+			actions:  make(map[Severity][]Action),
+		}
+
+		for sev, names := range pc.Actions {
+			for _, name := range names {
+				act, ok := actions[name]
+				if !ok {
+					return nil, xerrors.Errorf("probe %q references unknown action %q", pc.Type, name)
+				}
+				pr.actions[Severity(sev)] = append(pr.actions[Severity(sev)], act)
+			}
+		}
+
+		r.probes = append(r.probes, pr)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,110 @@
+package health
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func writeTempConfig(t *testing.T, contents string) string {
+	t.Helper()
+
+	f, err := ioutil.TempFile("", "lotus-health-*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(contents); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Remove(f.Name()) })
+
+	return f.Name()
+}
+
+func TestLoadRunnerBuildsProbesAndActions(t *testing.T) {
+	path := writeTempConfig(t, `
+actions:
+  restart:
+    type: systemd
+    unit: lotus-daemon
+  notify:
+    type: log
+probes:
+  - type: peer-count
+    min: 10
+    interval: 30s
+    actions:
+      warn:
+        - restart
+        - notify
+`)
+
+	r, err := LoadRunner(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.probes) != 1 {
+		t.Fatalf("expected 1 configured probe, got %d", len(r.probes))
+	}
+
+	pr := r.probes[0]
+	pc, ok := pr.probe.(*PeerCountBelow)
+	if !ok {
+		t.Fatalf("expected a *PeerCountBelow probe, got %T", pr.probe)
+	}
+	if pc.Min != 10 {
+		t.Fatalf("expected min 10, got %d", pc.Min)
+	}
+
+	acts := pr.actions[SeverityWarn]
+	if len(acts) != 2 {
+		t.Fatalf("expected 2 actions wired for severity warn, got %d", len(acts))
+	}
+}
+
+func TestLoadRunnerUnknownProbeType(t *testing.T) {
+	path := writeTempConfig(t, `
+probes:
+  - type: does-not-exist
+`)
+
+	if _, err := LoadRunner(path, nil); err == nil {
+		t.Fatal("expected an error for an unknown probe type")
+	}
+}
+
+func TestLoadRunnerUnknownActionType(t *testing.T) {
+	path := writeTempConfig(t, `
+actions:
+  bogus:
+    type: does-not-exist
+`)
+
+	if _, err := LoadRunner(path, nil); err == nil {
+		t.Fatal("expected an error for an unknown action type")
+	}
+}
+
+func TestLoadRunnerProbeReferencesUnknownAction(t *testing.T) {
+	path := writeTempConfig(t, `
+probes:
+  - type: peer-count
+    actions:
+      warn:
+        - does-not-exist
+`)
+
+	if _, err := LoadRunner(path, nil); err == nil {
+		t.Fatal("expected an error for a probe referencing an unknown action")
+	}
+}
+
+func TestLoadRunnerMissingFile(t *testing.T) {
+	if _, err := LoadRunner("/nonexistent/lotus-health.yaml", nil); err == nil {
+		t.Fatal("expected an error for a missing config file")
+	}
+}
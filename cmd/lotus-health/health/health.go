@@ -0,0 +1,7 @@
+package health
+
+import (
+	logging "github.com/ipfs/go-log"
+)
+
+var log = logging.Logger("lotus-health")
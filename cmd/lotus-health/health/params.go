@@ -0,0 +1,72 @@
+package health
+
+import (
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+func paramString(params map[string]interface{}, name, def string) string {
+	v, ok := params[name]
+	if !ok {
+		return def
+	}
+	s, ok := v.(string)
+	if !ok {
+		return def
+	}
+	return s
+}
+
+func paramInt(params map[string]interface{}, name string, def int) int {
+	v, ok := params[name]
+	if !ok {
+		return def
+	}
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	default:
+		return def
+	}
+}
+
+func paramUint64(params map[string]interface{}, name string, def uint64) uint64 {
+	return uint64(paramInt(params, name, int(def)))
+}
+
+// paramStringSlice reads a YAML/JSON array param into a []string, skipping
+// any element that isn't a string. A missing param yields a nil slice.
+func paramStringSlice(params map[string]interface{}, name string) []string {
+	v, ok := params[name]
+	if !ok {
+		return nil
+	}
+
+	raw, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var out []string
+	for _, e := range raw {
+		if s, ok := e.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func paramDuration(params map[string]interface{}, name string, def time.Duration) (time.Duration, error) {
+	v, ok := params[name]
+	if !ok {
+		return def, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return 0, xerrors.Errorf("param %q must be a duration string", name)
+	}
+	return time.ParseDuration(s)
+}
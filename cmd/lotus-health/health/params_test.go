@@ -0,0 +1,70 @@
+package health
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParamIntDefaultsWhenMissing(t *testing.T) {
+	if v := paramInt(map[string]interface{}{}, "min", 5); v != 5 {
+		t.Fatalf("expected default 5, got %d", v)
+	}
+}
+
+func TestParamIntFromFloat64(t *testing.T) {
+	// JSON numbers decode into interface{} as float64
+	params := map[string]interface{}{"min": float64(7)}
+	if v := paramInt(params, "min", 5); v != 7 {
+		t.Fatalf("expected 7, got %d", v)
+	}
+}
+
+func TestParamDurationParsesString(t *testing.T) {
+	params := map[string]interface{}{"max-lag": "90s"}
+	d, err := paramDuration(params, "max-lag", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != 90*time.Second {
+		t.Fatalf("expected 90s, got %s", d)
+	}
+}
+
+func TestParamDurationRejectsWrongType(t *testing.T) {
+	params := map[string]interface{}{"max-lag": 90}
+	if _, err := paramDuration(params, "max-lag", time.Minute); err == nil {
+		t.Fatal("expected an error for a non-string duration param")
+	}
+}
+
+func TestParamStringSliceFromInterfaceSlice(t *testing.T) {
+	// YAML/JSON arrays decode into interface{} as []interface{}
+	params := map[string]interface{}{"args": []interface{}{"-v", "--tag", "x"}}
+	got := paramStringSlice(params, "args")
+	want := []string{"-v", "--tag", "x"}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestParamStringSliceDefaultsWhenMissing(t *testing.T) {
+	if got := paramStringSlice(map[string]interface{}{}, "args"); got != nil {
+		t.Fatalf("expected nil for a missing param, got %v", got)
+	}
+}
+
+func TestParamDurationDefaultsWhenMissing(t *testing.T) {
+	d, err := paramDuration(map[string]interface{}{}, "max-lag", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != time.Minute {
+		t.Fatalf("expected default 1m, got %s", d)
+	}
+}
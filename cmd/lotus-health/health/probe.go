@@ -0,0 +1,40 @@
+package health
+
+import (
+	"context"
+
+	"github.com/filecoin-project/lotus/api"
+)
+
+// Severity indicates how urgently a failed probe should be acted upon.
+type Severity string
+
+const (
+	SeverityWarn Severity = "warn"
+	SeverityCrit Severity = "crit"
+)
+
+// ProbeResult is the outcome of a single probe check.
+type ProbeResult struct {
+	Healthy  bool
+	Severity Severity
+	Message  string
+}
+
+// Probe checks one aspect of a lotus node's health.
+type Probe interface {
+	Name() string
+	Check(ctx context.Context, a api.FullNode) (ProbeResult, error)
+}
+
+// probeFactories maps a probe's config `type` to a constructor that builds
+// it from the other keys of the probe's config entry.
+var probeFactories = map[string]func(params map[string]interface{}) (Probe, error){
+	"chain-head-stalled":    newChainHeadStalled,
+	"sync-lag":              newSyncLagExceeded,
+	"peer-count":            newPeerCountBelow,
+	"mpool-backlog":         newMpoolBacklog,
+	"miner-missed-deadline": newMinerMissedDeadline,
+	"disk-space":            newDiskSpaceLow,
+	"rpc-latency":           newRPCLatency,
+}
@@ -0,0 +1,193 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"time"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/address"
+	"golang.org/x/xerrors"
+)
+
+type SyncLagExceeded struct {
+	MaxLag time.Duration
+}
+
+func newSyncLagExceeded(params map[string]interface{}) (Probe, error) {
+	maxLag, err := paramDuration(params, "max-lag", 5*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	return &SyncLagExceeded{MaxLag: maxLag}, nil
+}
+
+func (p *SyncLagExceeded) Name() string { return "sync-lag" }
+
+func (p *SyncLagExceeded) Check(ctx context.Context, a api.FullNode) (ProbeResult, error) {
+	head, err := a.ChainHead(ctx)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+
+	lag := time.Since(time.Unix(int64(head.MinTimestamp()), 0))
+	if lag > p.MaxLag {
+		return ProbeResult{
+			Severity: SeverityWarn,
+			Message:  fmt.Sprintf("chain head is %s behind", lag),
+		}, nil
+	}
+
+	return ProbeResult{Healthy: true}, nil
+}
+
+type PeerCountBelow struct {
+	Min int
+}
+
+func newPeerCountBelow(params map[string]interface{}) (Probe, error) {
+	return &PeerCountBelow{Min: paramInt(params, "min", 5)}, nil
+}
+
+func (p *PeerCountBelow) Name() string { return "peer-count" }
+
+func (p *PeerCountBelow) Check(ctx context.Context, a api.FullNode) (ProbeResult, error) {
+	peers, err := a.NetPeers(ctx)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+
+	if len(peers) < p.Min {
+		return ProbeResult{
+			Severity: SeverityWarn,
+			Message:  fmt.Sprintf("only %d peers connected, want at least %d", len(peers), p.Min),
+		}, nil
+	}
+
+	return ProbeResult{Healthy: true}, nil
+}
+
+type MpoolBacklog struct {
+	Max int
+}
+
+func newMpoolBacklog(params map[string]interface{}) (Probe, error) {
+	return &MpoolBacklog{Max: paramInt(params, "max", 1000)}, nil
+}
+
+func (p *MpoolBacklog) Name() string { return "mpool-backlog" }
+
+func (p *MpoolBacklog) Check(ctx context.Context, a api.FullNode) (ProbeResult, error) {
+	pending, err := a.MpoolPending(ctx, nil)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+
+	if len(pending) > p.Max {
+		return ProbeResult{
+			Severity: SeverityWarn,
+			Message:  fmt.Sprintf("%d messages pending in mpool, want at most %d", len(pending), p.Max),
+		}, nil
+	}
+
+	return ProbeResult{Healthy: true}, nil
+}
+
+type MinerMissedDeadline struct {
+	Miner address.Address
+	Grace time.Duration
+}
+
+func newMinerMissedDeadline(params map[string]interface{}) (Probe, error) {
+	addr, err := address.NewFromString(paramString(params, "miner", ""))
+	if err != nil {
+		return nil, xerrors.Errorf("parsing miner address: %w", err)
+	}
+
+	grace, err := paramDuration(params, "grace", time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MinerMissedDeadline{Miner: addr, Grace: grace}, nil
+}
+
+func (p *MinerMissedDeadline) Name() string { return "miner-missed-deadline" }
+
+func (p *MinerMissedDeadline) Check(ctx context.Context, a api.FullNode) (ProbeResult, error) {
+	deadline, err := a.StateMinerProvingDeadline(ctx, p.Miner)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+
+	if time.Since(deadline.Close) > p.Grace {
+		return ProbeResult{
+			Severity: SeverityCrit,
+			Message:  fmt.Sprintf("miner %s missed its proving deadline", p.Miner),
+		}, nil
+	}
+
+	return ProbeResult{Healthy: true}, nil
+}
+
+type DiskSpaceLow struct {
+	Path         string
+	MinFreeBytes uint64
+}
+
+func newDiskSpaceLow(params map[string]interface{}) (Probe, error) {
+	return &DiskSpaceLow{
+		Path:         paramString(params, "path", "/"),
+		MinFreeBytes: paramUint64(params, "min-free-bytes", 1<<30),
+	}, nil
+}
+
+func (p *DiskSpaceLow) Name() string { return "disk-space" }
+
+func (p *DiskSpaceLow) Check(ctx context.Context, a api.FullNode) (ProbeResult, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(p.Path, &stat); err != nil {
+		return ProbeResult{}, xerrors.Errorf("statfs %s: %w", p.Path, err)
+	}
+
+	free := stat.Bavail * uint64(stat.Bsize)
+	if free < p.MinFreeBytes {
+		return ProbeResult{
+			Severity: SeverityCrit,
+			Message:  fmt.Sprintf("only %d bytes free on %s, want at least %d", free, p.Path, p.MinFreeBytes),
+		}, nil
+	}
+
+	return ProbeResult{Healthy: true}, nil
+}
+
+type RPCLatency struct {
+	Max time.Duration
+}
+
+func newRPCLatency(params map[string]interface{}) (Probe, error) {
+	max, err := paramDuration(params, "max", 3*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return &RPCLatency{Max: max}, nil
+}
+
+func (p *RPCLatency) Name() string { return "rpc-latency" }
+
+func (p *RPCLatency) Check(ctx context.Context, a api.FullNode) (ProbeResult, error) {
+	start := time.Now()
+	if _, err := a.ChainHead(ctx); err != nil {
+		return ProbeResult{}, err
+	}
+
+	if lat := time.Since(start); lat > p.Max {
+		return ProbeResult{
+			Severity: SeverityWarn,
+			Message:  fmt.Sprintf("ChainHead took %s, want at most %s", lat, p.Max),
+		}, nil
+	}
+
+	return ProbeResult{Healthy: true}, nil
+}
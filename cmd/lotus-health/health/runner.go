@@ -0,0 +1,63 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/filecoin-project/lotus/api"
+)
+
+// probeRun pairs a configured probe with its check interval and the actions
+// to fire for each severity it can report.
+type probeRun struct {
+	probe    Probe
+	interval time.Duration
+	actions  map[Severity][]Action
+}
+
+// Runner periodically checks a set of probes against a node and fires the
+// configured actions whenever a probe reports an unhealthy result.
+type Runner struct {
+	api    api.FullNode
+	probes []*probeRun
+}
+
+// Run checks every configured probe on its own interval until ctx is
+// cancelled.
+func (r *Runner) Run(ctx context.Context) error {
+	for _, pr := range r.probes {
+		go r.runProbe(ctx, pr)
+	}
+
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (r *Runner) runProbe(ctx context.Context, pr *probeRun) {
+	ticker := time.NewTicker(pr.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			res, err := pr.probe.Check(ctx, r.api)
+			if err != nil {
+				log.Warnf("probe %s failed: %s", pr.probe.Name(), err)
+				continue
+			}
+
+			if res.Healthy {
+				continue
+			}
+
+			log.Warnf("probe %s unhealthy: %s", pr.probe.Name(), res.Message)
+			for _, act := range pr.actions[res.Severity] {
+				if err := act.Fire(ctx, pr.probe.Name(), res); err != nil {
+					log.Warnf("action %s failed: %s", act.Name(), err)
+				}
+			}
+		}
+	}
+}
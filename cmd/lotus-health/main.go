@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"os"
 	"time"
@@ -10,16 +9,16 @@ import (
 	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/api/client"
 	"github.com/filecoin-project/lotus/build"
+	"github.com/filecoin-project/lotus/cmd/lotus-health/health"
 	"github.com/filecoin-project/lotus/lib/jsonrpc"
 	"github.com/filecoin-project/lotus/node/repo"
-	cid "github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log"
 	manet "github.com/multiformats/go-multiaddr-net"
 	"golang.org/x/xerrors"
 	"gopkg.in/urfave/cli.v2"
 )
 
-var log = logging.Logger("lotus-seed")
+var log = logging.Logger("lotus-health")
 
 func main() {
 	logging.SetLogLevel("*", "INFO")
@@ -27,7 +26,7 @@ func main() {
 	log.Info("Starting health agent")
 
 	local := []*cli.Command{
-		watchHeadCmd,
+		runCmd,
 	}
 
 	app := &cli.App{
@@ -43,139 +42,41 @@ func main() {
 	}
 }
 
-var watchHeadCmd = &cli.Command{
-	Name: "watch-head",
+var runCmd = &cli.Command{
+	Name: "run",
 	Flags: []cli.Flag{
 		&cli.StringFlag{
 			Name:  "repo",
 			Value: "~/.lotus",
 			Usage: "lotus repo path",
 		},
-		&cli.IntFlag{
-			Name:  "threshold",
-			Value: 3,
-			Usage: "number of times head remains unchanged before failing health check",
-		},
-		&cli.IntFlag{
-			Name:  "interval",
-			Value: 45,
-			Usage: "interval in seconds between chain head checks",
-		},
 		&cli.StringFlag{
-			Name:  "systemd-unit",
-			Value: "lotus-daemon.service",
-			Usage: "systemd unit name to restart on health check failure",
+			Name:  "config",
+			Value: "~/.lotus-health.yaml",
+			Usage: "path to the probe/action config",
 		},
 	},
 	Action: func(c *cli.Context) error {
-		repo := c.String("repo")
-		threshold := c.Int("threshold")
-		interval := time.Duration(c.Int("interval"))
-		name := c.String("systemd-unit")
-
-		var headCheckWindow [][]cid.Cid
 		ctx := context.Background()
 
-		api, closer, err := GetFullNodeAPI(repo)
+		napi, closer, err := GetFullNodeAPI(c.String("repo"))
 		if err != nil {
 			return err
 		}
 		defer closer()
 
-		if err := WaitForSyncComplete(ctx, api); err != nil {
-			log.Fatal(err)
-		}
-
-		ch := make(chan [][]cid.Cid, 1)
-		aCh := make(chan interface{}, 1)
-
-		go func() {
-			for {
-				headCheckWindow, err = updateWindow(ctx, api, headCheckWindow, threshold, ch)
-				if err != nil {
-					log.Fatal(err)
-				}
-				time.Sleep(interval * time.Second)
-			}
-		}()
-
-		go func() {
-			for {
-				result, err := alertHandler(name, aCh)
-				if err != nil {
-					log.Fatal(err)
-				}
-				if result != "done" {
-					log.Fatal("systemd unit failed to restart:", result)
-				}
-				log.Info("restarting health agent")
-				os.Exit(130)
-			}
-		}()
-
-		for {
-			ok := checkWindow(ch, int(interval))
-			if !ok {
-				log.Warn("chain head has not updated. Restarting systemd service")
-				aCh <- nil
-				break
-			}
-			log.Info("chain head is healthy")
+		if err := WaitForSyncComplete(ctx, napi); err != nil {
+			return err
 		}
-		return nil
-	},
-}
-
-func checkWindow(ch chan [][]cid.Cid, t int) bool {
-	select {
-	case window := <-ch:
-		var dup int
-		windowLen := len(window)
-		if windowLen >= t {
-		cidWindow:
-			for i, cids := range window {
-				fmt.Print("yo")
-				next := windowLen - 1 - i
-				// if array length is different, head is changing
-				if next >= 1 && len(window[next]) != len(window[next-1]) {
-					break cidWindow
-				}
-				// if cids are different, head is changing
-				for j := range cids {
-					if next >= 1 && window[next][j] != window[next-1][j] {
-						break cidWindow
-					}
-				}
-				if i < (t - 1) {
-					dup++
-				}
-			}
 
-			if dup == (t - 1) {
-				return false
-			}
+		runner, err := health.LoadRunner(c.String("config"), napi)
+		if err != nil {
+			return err
 		}
-		return true
-	}
-}
 
-func updateWindow(ctx context.Context, a api.FullNode, w [][]cid.Cid, t int, ch chan [][]cid.Cid) ([][]cid.Cid, error) {
-	head, err := a.ChainHead(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	window := appendCIDsToWindow(w, head.Cids(), t)
-	ch <- window
-	return window, nil
-}
-
-func appendCIDsToWindow(w [][]cid.Cid, c []cid.Cid, t int) [][]cid.Cid {
-	offset := len(w) - t + 1
-	if offset >= 0 {
-		return append(w[offset:], c)
-	}
-	return append(w, c)
+		log.Info("starting health daemon")
+		return runner.Run(ctx)
+	},
 }
 
 func getAPI(path string) (string, http.Header, error) {
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/filecoin-project/lotus/build"
+	"github.com/filecoin-project/lotus/chain/address"
+	"github.com/filecoin-project/lotus/chain/gen"
+	datastore "github.com/ipfs/go-datastore"
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	logging "github.com/ipfs/go-log"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"golang.org/x/xerrors"
+	"gopkg.in/urfave/cli.v2"
+)
+
+var log = logging.Logger("lotus-seed")
+
+func main() {
+	logging.SetLogLevel("*", "INFO")
+
+	local := []*cli.Command{
+		preSealCmd,
+		genesisCmd,
+	}
+
+	app := &cli.App{
+		Name:     "lotus-seed",
+		Usage:    "Tools for generating genesis pre-seal records",
+		Version:  build.UserVersion,
+		Commands: local,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Warn(err)
+		return
+	}
+}
+
+var preSealCmd = &cli.Command{
+	Name:  "pre-seal",
+	Usage: "generate pre-sealed sectors for a genesis miner",
+	Flags: []cli.Flag{
+		&cli.Uint64Flag{
+			Name:  "sector-size",
+			Value: build.SectorSize,
+			Usage: "size of each generated sector",
+		},
+		&cli.IntFlag{
+			Name:  "num-sectors",
+			Value: 1,
+			Usage: "number of sectors to pre-seal",
+		},
+		&cli.StringFlag{
+			Name:  "out",
+			Value: "preseal.json",
+			Usage: "path to write the generated pre-seal records to, if --genesis-template is not set",
+		},
+		&cli.StringFlag{
+			Name:  "genesis-template",
+			Usage: "instead of writing a standalone pre-seal file, add a miner with these pre-seals straight into this genesis template",
+		},
+		&cli.StringFlag{
+			Name:  "owner",
+			Usage: "owner address for the new miner (required with --genesis-template)",
+		},
+		&cli.StringFlag{
+			Name:  "worker",
+			Usage: "worker address for the new miner (defaults to --owner)",
+		},
+		&cli.StringFlag{
+			Name:  "peer-id",
+			Usage: "peer ID for the new miner",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		n := c.Int("num-sectors")
+		if n <= 0 {
+			return xerrors.Errorf("--num-sectors must be positive, got %d", n)
+		}
+		size := c.Uint64("sector-size")
+
+		preseals := make([]gen.PreSealedSector, n)
+		for i := range preseals {
+			preseals[i].SectorID = uint64(i + 1)
+			preseals[i].Size = size
+
+			if err := fakeSeal(&preseals[i]); err != nil {
+				return err
+			}
+		}
+
+		if tmplPath := c.String("genesis-template"); tmplPath != "" {
+			return addMinerToTemplate(c, tmplPath, preseals)
+		}
+
+		out, err := os.Create(c.String("out"))
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+
+		if err := json.NewEncoder(out).Encode(preseals); err != nil {
+			return err
+		}
+
+		fmt.Printf("wrote %d pre-sealed sectors to %s\n", n, c.String("out"))
+		return nil
+	},
+}
+
+// addMinerToTemplate loads the genesis template at tmplPath, appends a new
+// miner backed by preseals, and writes the template back out in place, so
+// an operator doesn't have to hand-splice pre-seal records into the
+// template themselves.
+func addMinerToTemplate(c *cli.Context, tmplPath string, preseals []gen.PreSealedSector) error {
+	if c.String("owner") == "" {
+		return xerrors.Errorf("--owner is required with --genesis-template")
+	}
+
+	owner, err := address.NewFromString(c.String("owner"))
+	if err != nil {
+		return xerrors.Errorf("parsing --owner: %w", err)
+	}
+
+	worker := owner
+	if w := c.String("worker"); w != "" {
+		worker, err = address.NewFromString(w)
+		if err != nil {
+			return xerrors.Errorf("parsing --worker: %w", err)
+		}
+	}
+
+	var pid peer.ID
+	if s := c.String("peer-id"); s != "" {
+		pid, err = peer.IDB58Decode(s)
+		if err != nil {
+			return xerrors.Errorf("parsing --peer-id: %w", err)
+		}
+	}
+
+	tmpl, err := gen.LoadGenesisTemplate(tmplPath)
+	if err != nil {
+		return xerrors.Errorf("loading genesis template: %w", err)
+	}
+
+	tmpl.Miners = append(tmpl.Miners, gen.TemplateMiner{
+		Owner:      owner,
+		Worker:     worker,
+		PeerID:     pid,
+		SectorSize: c.Uint64("sector-size"),
+		PreSeals:   preseals,
+	})
+
+	out, err := os.Create(tmplPath)
+	if err != nil {
+		return xerrors.Errorf("opening genesis template for writing: %w", err)
+	}
+	defer out.Close()
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(tmpl); err != nil {
+		return xerrors.Errorf("writing genesis template: %w", err)
+	}
+
+	fmt.Printf("added miner with %d pre-sealed sectors to %s\n", len(preseals), tmplPath)
+	return nil
+}
+
+var genesisCmd = &cli.Command{
+	Name:  "genesis",
+	Usage: "build a genesis block from a template and export it as a CAR file",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "template",
+			Required: true,
+			Usage:    "path to a genesis template",
+		},
+		&cli.StringFlag{
+			Name:  "out",
+			Value: "genesis.car",
+			Usage: "path to write the genesis CAR file to",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		tmpl, err := gen.LoadGenesisTemplate(c.String("template"))
+		if err != nil {
+			return err
+		}
+
+		bs := bstore.NewBlockstore(datastore.NewMapDatastore())
+
+		if _, err := gen.MakeGenesisBlockFromTemplate(bs, tmpl, c.String("out")); err != nil {
+			return err
+		}
+
+		fmt.Printf("wrote genesis car to %s\n", c.String("out"))
+		return nil
+	},
+}